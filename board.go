@@ -0,0 +1,88 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Board represents the fields of a Grafana dashboard that the SDK itself
+// needs to inspect; callers that need the full document should work with
+// the raw JSON directly, as DashboardImporter does.
+type Board struct {
+	ID    uint   `json:"id,omitempty"`
+	UID   string `json:"uid,omitempty"`
+	Title string `json:"title"`
+}
+
+// BoardProperties carries the metadata Grafana returns alongside a
+// dashboard, such as its folder and version.
+type BoardProperties struct {
+	FolderID  uint   `json:"folderId"`
+	IsStarred bool   `json:"isStarred"`
+	Slug      string `json:"slug"`
+	Version   int    `json:"version"`
+}
+
+// GetDashboardByUID loads the dashboard stored under uid. Use IsNotFound on
+// the returned error to tell "no such dashboard" apart from other failures.
+func (r *Client) GetDashboardByUID(ctx context.Context, uid string) (Board, BoardProperties, error) {
+	raw, _, err := r.get(ctx, fmt.Sprintf("api/dashboards/uid/%s", uid), nil)
+	if err != nil {
+		return Board{}, BoardProperties{}, err
+	}
+	var resp struct {
+		Dashboard Board           `json:"dashboard"`
+		Meta      BoardProperties `json:"meta"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return Board{}, BoardProperties{}, fmt.Errorf("failed to decode dashboard %q: %w", uid, err)
+	}
+	return resp.Dashboard, resp.Meta, nil
+}
+
+// SetRawDashboard creates or updates a dashboard from its raw JSON
+// representation, overwriting any existing dashboard with the same UID.
+func (r *Client) SetRawDashboard(ctx context.Context, raw []byte) (StatusMessage, error) {
+	var dashboard map[string]interface{}
+	if err := json.Unmarshal(raw, &dashboard); err != nil {
+		return StatusMessage{}, fmt.Errorf("failed to parse dashboard: %w", err)
+	}
+
+	body, err := json.Marshal(struct {
+		Dashboard map[string]interface{} `json:"dashboard"`
+		Overwrite bool                   `json:"overwrite"`
+	}{Dashboard: dashboard, Overwrite: true})
+	if err != nil {
+		return StatusMessage{}, err
+	}
+
+	resp, _, err := r.post(ctx, "api/dashboards/db", nil, body)
+	if err != nil {
+		return StatusMessage{}, err
+	}
+	var msg StatusMessage
+	if err := json.Unmarshal(resp, &msg); err != nil {
+		return StatusMessage{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return msg, nil
+}
@@ -0,0 +1,239 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// ConflictPolicy controls what DashboardImporter does when the dashboard
+// being imported collides with one that already exists on the server.
+type ConflictPolicy int
+
+const (
+	// FailOnExist reports an error for the conflicting file and leaves the
+	// existing dashboard untouched. This is the default policy.
+	FailOnExist ConflictPolicy = iota
+
+	// Skip leaves the existing dashboard untouched and reports the file as
+	// skipped rather than failed.
+	Skip
+
+	// Overwrite replaces the existing dashboard, matching the historical
+	// behavior of the import-dashboards-raw example.
+	Overwrite
+
+	// RenameSuffix uploads the dashboard under a new title (and without its
+	// original UID) so it's created alongside the existing one instead of
+	// replacing it.
+	RenameSuffix
+)
+
+// DashboardImporter bulk-imports dashboard JSON files into Grafana, running
+// uploads concurrently with a bounded worker pool.
+type DashboardImporter struct {
+	Client *Client
+
+	// Concurrency bounds how many dashboards are uploaded at once. Values
+	// <= 0 default to 1.
+	Concurrency int
+
+	// DryRun reports what would be imported without uploading anything. The
+	// conflict check still looks up existing dashboards on the server.
+	DryRun bool
+
+	// ConflictPolicy controls what happens when a dashboard with the same
+	// UID already exists. Defaults to FailOnExist.
+	ConflictPolicy ConflictPolicy
+
+	// FolderID assigns every imported dashboard to the given folder by
+	// default; 0 keeps Grafana's default (General) folder. FolderForFile
+	// takes precedence over it when set.
+	FolderID uint
+
+	// FolderForFile, if set, is called with each file's path and overrides
+	// FolderID for that file.
+	FolderForFile func(path string) uint
+}
+
+// ImportResult is the outcome of importing a single file.
+type ImportResult struct {
+	Path    string
+	Skipped bool
+	Err     error
+}
+
+// ImportReport summarizes the outcome of an Import call, one ImportResult
+// per file that was processed.
+type ImportReport struct {
+	Results []ImportResult
+}
+
+// Succeeded returns the files that were imported (or would have been, in
+// dry-run mode) without error.
+func (rep *ImportReport) Succeeded() []ImportResult {
+	var out []ImportResult
+	for _, res := range rep.Results {
+		if res.Err == nil && !res.Skipped {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// Failed returns the files whose import errored.
+func (rep *ImportReport) Failed() []ImportResult {
+	var out []ImportResult
+	for _, res := range rep.Results {
+		if res.Err != nil {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// Import walks fsys rooted at dir, uploading every *.json file it finds as a
+// dashboard. Files are processed concurrently, bounded by imp.Concurrency,
+// and each file's outcome is independent: one failure never aborts the rest
+// of the batch.
+func (imp *DashboardImporter) Import(ctx context.Context, fsys fs.FS, dir string) (*ImportReport, error) {
+	var files []string
+	err := fs.WalkDir(fsys, dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", dir, err)
+	}
+
+	concurrency := imp.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make([]ImportResult, len(files))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, file string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = imp.importFile(ctx, fsys, file)
+		}(i, file)
+	}
+	wg.Wait()
+
+	return &ImportReport{Results: results}, nil
+}
+
+func (imp *DashboardImporter) importFile(ctx context.Context, fsys fs.FS, path string) ImportResult {
+	raw, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return ImportResult{Path: path, Err: fmt.Errorf("failed to read %s: %w", path, err)}
+	}
+
+	var board struct {
+		UID   string `json:"uid"`
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal(raw, &board); err != nil {
+		return ImportResult{Path: path, Err: fmt.Errorf("failed to parse %s: %w", path, err)}
+	}
+
+	if imp.ConflictPolicy != Overwrite && board.UID != "" {
+		_, _, err := imp.Client.GetDashboardByUID(ctx, board.UID)
+		switch {
+		case IsNotFound(err):
+			// no existing dashboard, nothing to reconcile
+		case err != nil:
+			return ImportResult{Path: path, Err: fmt.Errorf("failed to check for existing dashboard %q: %w", board.UID, err)}
+		default:
+			switch imp.ConflictPolicy {
+			case Skip:
+				return ImportResult{Path: path, Skipped: true}
+			case RenameSuffix:
+				raw, err = renamedDashboard(raw, board.Title+" (imported)")
+				if err != nil {
+					return ImportResult{Path: path, Err: fmt.Errorf("failed to rename %s: %w", path, err)}
+				}
+			default: // FailOnExist
+				return ImportResult{Path: path, Err: fmt.Errorf("dashboard %q from %s already exists", board.UID, path)}
+			}
+		}
+	}
+
+	folderID := imp.FolderID
+	if imp.FolderForFile != nil {
+		folderID = imp.FolderForFile(path)
+	}
+	if folderID != 0 {
+		raw, err = withFolderID(raw, folderID)
+		if err != nil {
+			return ImportResult{Path: path, Err: fmt.Errorf("failed to set folder for %s: %w", path, err)}
+		}
+	}
+
+	if imp.DryRun {
+		return ImportResult{Path: path}
+	}
+
+	if _, err := imp.Client.SetRawDashboard(ctx, raw); err != nil {
+		return ImportResult{Path: path, Err: fmt.Errorf("failed to import %s: %w", path, err)}
+	}
+	return ImportResult{Path: path}
+}
+
+// renamedDashboard returns a copy of raw with its title replaced and its
+// UID cleared so Grafana creates a new dashboard instead of colliding with
+// the existing one.
+func renamedDashboard(raw []byte, title string) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	doc["title"] = title
+	delete(doc, "uid")
+	delete(doc, "id")
+	return json.Marshal(doc)
+}
+
+// withFolderID returns a copy of raw annotated with the target folder so
+// the upload lands in the intended folder instead of Grafana's default.
+func withFolderID(raw []byte, folderID uint) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	doc["folderId"] = folderID
+	return json.Marshal(doc)
+}
@@ -0,0 +1,192 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+// newImporterTestServer returns a Grafana stand-in that knows about a
+// single existing dashboard (existingUID) and fails lookups for
+// errorUID with a 500, so tests can exercise the found/not-found/error
+// branches of the conflict check. Every successful POST to
+// api/dashboards/db is recorded in posted for later inspection.
+func newImporterTestServer(t *testing.T, existingUID, errorUID string) (*httptest.Server, *[]map[string]interface{}) {
+	t.Helper()
+	var mu sync.Mutex
+	var posted []map[string]interface{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case strings.HasPrefix(req.URL.Path, "/api/dashboards/uid/"):
+			uid := strings.TrimPrefix(req.URL.Path, "/api/dashboards/uid/")
+			switch uid {
+			case existingUID:
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"dashboard": map[string]interface{}{"uid": uid, "title": "existing"},
+					"meta":      map[string]interface{}{},
+				})
+			case errorUID:
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]interface{}{"message": "boom"})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]interface{}{"message": "not found"})
+			}
+		case req.URL.Path == "/api/dashboards/db" && req.Method == http.MethodPost:
+			var body struct {
+				Dashboard map[string]interface{} `json:"dashboard"`
+				Overwrite bool                    `json:"overwrite"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode posted dashboard: %v", err)
+			}
+			mu.Lock()
+			posted = append(posted, body.Dashboard)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(StatusMessage{})
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &posted
+}
+
+func newImporterTestClient(t *testing.T, srv *httptest.Server) *Client {
+	t.Helper()
+	c, err := NewClient(srv.URL, "test-key", srv.Client())
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return c
+}
+
+func dashboardFile(uid, title string) string {
+	b, _ := json.Marshal(map[string]interface{}{"uid": uid, "title": title})
+	return string(b)
+}
+
+func TestDashboardImporterConflictPolicies(t *testing.T) {
+	const existingUID = "existing-uid"
+	const errorUID = "error-uid"
+
+	cases := []struct {
+		name       string
+		policy     ConflictPolicy
+		uid        string
+		wantSkip   bool
+		wantErr    bool
+		wantPosted bool
+	}{
+		{name: "skip existing", policy: Skip, uid: existingUID, wantSkip: true},
+		{name: "fail on existing", policy: FailOnExist, uid: existingUID, wantErr: true},
+		{name: "rename existing", policy: RenameSuffix, uid: existingUID, wantPosted: true},
+		{name: "missing uid uploads under skip", policy: Skip, uid: "missing-uid", wantPosted: true},
+		{name: "missing uid uploads under fail-on-exist", policy: FailOnExist, uid: "missing-uid", wantPosted: true},
+		{name: "lookup error surfaces as failure", policy: FailOnExist, uid: errorUID, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, posted := newImporterTestServer(t, existingUID, errorUID)
+			imp := &DashboardImporter{
+				Client:         newImporterTestClient(t, srv),
+				ConflictPolicy: tc.policy,
+			}
+			fsys := fstest.MapFS{
+				"board.json": {Data: []byte(dashboardFile(tc.uid, "my dashboard"))},
+			}
+
+			report, err := imp.Import(context.Background(), fsys, ".")
+			if err != nil {
+				t.Fatalf("Import failed: %v", err)
+			}
+			if len(report.Results) != 1 {
+				t.Fatalf("got %d results, want 1", len(report.Results))
+			}
+			res := report.Results[0]
+
+			if res.Skipped != tc.wantSkip {
+				t.Errorf("Skipped = %v, want %v", res.Skipped, tc.wantSkip)
+			}
+			if (res.Err != nil) != tc.wantErr {
+				t.Errorf("Err = %v, want non-nil: %v", res.Err, tc.wantErr)
+			}
+			if tc.wantErr && tc.uid == errorUID && IsNotFound(res.Err) {
+				t.Errorf("lookup error was misreported as not-found: %v", res.Err)
+			}
+
+			gotPosted := len(*posted) == 1
+			if gotPosted != tc.wantPosted {
+				t.Errorf("posted = %v, want %v", gotPosted, tc.wantPosted)
+			}
+		})
+	}
+}
+
+func TestDashboardImporterRenameSuffixStripsUID(t *testing.T) {
+	const existingUID = "existing-uid"
+	srv, posted := newImporterTestServer(t, existingUID, "error-uid")
+	imp := &DashboardImporter{
+		Client:         newImporterTestClient(t, srv),
+		ConflictPolicy: RenameSuffix,
+	}
+	fsys := fstest.MapFS{
+		"board.json": {Data: []byte(dashboardFile(existingUID, "my dashboard"))},
+	}
+
+	if _, err := imp.Import(context.Background(), fsys, "."); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(*posted) != 1 {
+		t.Fatalf("got %d posted dashboards, want 1", len(*posted))
+	}
+	board := (*posted)[0]
+	if _, ok := board["uid"]; ok {
+		t.Errorf("renamed dashboard still carries its original uid: %v", board)
+	}
+	if title, _ := board["title"].(string); title == "my dashboard" {
+		t.Errorf("renamed dashboard kept its original title: %q", title)
+	}
+}
+
+func TestDashboardImporterConcurrentResultsMatchFiles(t *testing.T) {
+	srv, _ := newImporterTestServer(t, "existing-uid", "error-uid")
+	imp := &DashboardImporter{
+		Client:      newImporterTestClient(t, srv),
+		Concurrency: 4,
+	}
+
+	fsys := fstest.MapFS{}
+	want := map[string]string{}
+	for i := 0; i < 10; i++ {
+		name := strings.Repeat("x", i+1) + ".json"
+		uid := "missing-" + name
+		fsys[name] = &fstest.MapFile{Data: []byte(dashboardFile(uid, name))}
+		want[name] = uid
+	}
+
+	report, err := imp.Import(context.Background(), fsys, ".")
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(report.Results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(report.Results), len(want))
+	}
+	for _, res := range report.Results {
+		if _, ok := want[res.Path]; !ok {
+			t.Errorf("unexpected result for path %q", res.Path)
+		}
+		if res.Err != nil {
+			t.Errorf("unexpected error for %q: %v", res.Path, res.Err)
+		}
+	}
+}
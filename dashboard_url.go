@@ -0,0 +1,83 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// URLOption configures the outgoing request made by SetDashboardFromURL
+// before it is sent.
+type URLOption func(*http.Request)
+
+// WithBasicAuth authenticates the fetch with HTTP Basic credentials, e.g.
+// when pulling a dashboard from a private git raw-file endpoint.
+func WithBasicAuth(user, pass string) URLOption {
+	return func(req *http.Request) {
+		req.SetBasicAuth(user, pass)
+	}
+}
+
+// WithBearerToken authenticates the fetch with a bearer token, e.g. when
+// pulling a dashboard from an artifact store.
+func WithBearerToken(token string) URLOption {
+	return func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// WithHeader sets an arbitrary header on the fetch request.
+func WithHeader(key, value string) URLOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+// SetDashboardFromURL fetches a dashboard JSON document from url and
+// uploads it via SetRawDashboard.
+func (r *Client) SetDashboardFromURL(ctx context.Context, url string, opts ...URLOption) (StatusMessage, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return StatusMessage{}, err
+	}
+	req = req.WithContext(ctx)
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return StatusMessage{}, fmt.Errorf("failed to fetch dashboard from %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return StatusMessage{}, fmt.Errorf("failed to read dashboard from %s: %w", url, err)
+	}
+	if resp.StatusCode >= 400 {
+		return StatusMessage{}, fmt.Errorf("failed to fetch dashboard from %s: status %d", url, resp.StatusCode)
+	}
+
+	return r.SetRawDashboard(ctx, raw)
+}
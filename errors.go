@@ -0,0 +1,84 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned by Client's request methods whenever Grafana
+// responds with a status code >= 400. It carries the raw response so
+// callers don't have to re-parse the body themselves to find out what
+// went wrong.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response, e.g. 404.
+	StatusCode int
+	// Status is the HTTP status line, e.g. "404 Not Found".
+	Status string
+	// Message is Grafana's own error message, extracted from the response
+	// body when it carries one.
+	Message string
+	// Body is the raw, unparsed response body.
+	Body []byte
+}
+
+func newAPIError(statusCode int, status string, body []byte) *APIError {
+	err := &APIError{StatusCode: statusCode, Status: status, Body: body}
+	var msg StatusMessage
+	if jsonErr := json.Unmarshal(body, &msg); jsonErr == nil && msg.Message != nil {
+		err.Message = *msg.Message
+	}
+	return err
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("grafana API error: %s (%s)", e.Message, e.Status)
+	}
+	return fmt.Sprintf("grafana API error: %s", e.Status)
+}
+
+// IsNotFound reports whether err is an *APIError for a 404 response.
+func IsNotFound(err error) bool {
+	return isAPIStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an *APIError for a 409 response.
+func IsConflict(err error) bool {
+	return isAPIStatus(err, http.StatusConflict)
+}
+
+// IsUnauthorized reports whether err is an *APIError for a 401 response.
+func IsUnauthorized(err error) bool {
+	return isAPIStatus(err, http.StatusUnauthorized)
+}
+
+// IsRateLimited reports whether err is an *APIError for a 429 response.
+func IsRateLimited(err error) bool {
+	return isAPIStatus(err, http.StatusTooManyRequests)
+}
+
+func isAPIStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
@@ -0,0 +1,63 @@
+package sdk
+
+/*
+   Copyright 2016 Alexander I.Grafov <grafov@gmail.com>
+   Copyright 2016-2019 The Grafana SDK authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   ॐ तारे तुत्तारे तुरे स्व
+*/
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// RoundTripper performs a single HTTP round trip against the Grafana API.
+// It mirrors the Client's own request plumbing so middleware can observe
+// or modify every request the Client makes, including ones replayed by
+// the retry policy configured via WithRetry.
+type RoundTripper interface {
+	RoundTrip(ctx context.Context, method, query, rawPath string, params url.Values, body []byte) ([]byte, int, time.Duration, error)
+}
+
+// RoundTripperFunc adapts a function to a RoundTripper.
+type RoundTripperFunc func(ctx context.Context, method, query, rawPath string, params url.Values, body []byte) ([]byte, int, time.Duration, error)
+
+// RoundTrip calls f.
+func (f RoundTripperFunc) RoundTrip(ctx context.Context, method, query, rawPath string, params url.Values, body []byte) ([]byte, int, time.Duration, error) {
+	return f(ctx, method, query, rawPath, params, body)
+}
+
+// Middleware wraps a RoundTripper to observe or modify requests, e.g. for
+// logging, metrics, or tracing.
+type Middleware func(next RoundTripper) RoundTripper
+
+// Use appends a middleware to the Client's request chain. Middlewares run
+// in the order they were added, with the first one registered becoming the
+// outermost layer.
+func (r *Client) Use(mw Middleware) {
+	r.middlewares = append(r.middlewares, mw)
+}
+
+// transport builds the RoundTripper chain for a single request: the
+// client's own doRequestOnce wrapped by every registered middleware.
+func (r *Client) transport() RoundTripper {
+	var rt RoundTripper = RoundTripperFunc(r.doRequestOnce)
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		rt = r.middlewares[i](rt)
+	}
+	return rt
+}
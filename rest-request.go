@@ -22,20 +22,32 @@ package sdk
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // DefaultHTTPClient initialized Grafana with appropriate conditions.
 // It allows you globally redefine HTTP client.
 var DefaultHTTPClient = http.DefaultClient
 
+// retryableStatusCodes are the HTTP status codes that doRequest will
+// transparently retry when a retry policy has been configured.
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
 // Client uses Grafana REST API for interacting with Grafana server.
 type Client struct {
 	baseURL       string
@@ -43,6 +55,37 @@ type Client struct {
 	basicAuth     bool
 	client        *http.Client
 	customHeaders map[string]string
+	tokenSource   TokenSource
+	middlewares   []Middleware
+
+	retryMaxAttempts int
+	retryBaseDelay   time.Duration
+}
+
+// TokenSource supplies a bearer token for each outgoing request, fetched
+// fresh on every call rather than fixed at Client construction time. It
+// mirrors oauth2.TokenSource, so an oauth2.TokenSource value can be adapted
+// to it with a one-line wrapper.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// ClientOption configures optional behavior of a Client; pass one or more
+// to NewClient.
+type ClientOption func(*Client)
+
+// WithRetry enables automatic retries for requests that fail with a
+// network error or one of the transient HTTP statuses 429, 502, 503 or
+// 504. maxAttempts is the number of retries performed after the initial
+// request (so a value of 3 allows up to 4 attempts in total). Between
+// attempts the client waits for the duration given by the Grafana
+// `Retry-After` header, if present, or otherwise for baseDelay multiplied
+// by an exponentially increasing factor.
+func WithRetry(maxAttempts int, baseDelay time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retryMaxAttempts = maxAttempts
+		c.retryBaseDelay = baseDelay
+	}
 }
 
 // SetCustomHeaders - set additional headers that will be sent with each request
@@ -65,10 +108,14 @@ func (r *Client) SetOrgIDHeader(oid uint) {
 
 func (r *Client) WithOrgIDHeader(oid uint) *Client {
 	c := &Client{
-		baseURL:   r.baseURL,
-		key:       r.key,
-		basicAuth: r.basicAuth,
-		client:    r.client,
+		baseURL:          r.baseURL,
+		key:              r.key,
+		basicAuth:        r.basicAuth,
+		client:           r.client,
+		tokenSource:      r.tokenSource,
+		middlewares:      r.middlewares,
+		retryMaxAttempts: r.retryMaxAttempts,
+		retryBaseDelay:   r.retryBaseDelay,
 	}
 	c.SetOrgIDHeader(oid)
 	return c
@@ -88,8 +135,9 @@ type StatusMessage struct {
 
 // NewClient initializes client for interacting with an instance of Grafana server;
 // apiKeyOrBasicAuth accepts either 'username:password' basic authentication credentials,
-// or a Grafana API key
-func NewClient(apiURL, apiKeyOrBasicAuth string, client *http.Client) (*Client, error) {
+// or a Grafana API key. Optional behavior, such as automatic retries via
+// WithRetry, can be configured by passing ClientOptions.
+func NewClient(apiURL, apiKeyOrBasicAuth string, client *http.Client, opts ...ClientOption) (*Client, error) {
 	key := ""
 	basicAuth := strings.Contains(apiKeyOrBasicAuth, ":")
 	baseURL, err := url.Parse(apiURL)
@@ -102,7 +150,27 @@ func NewClient(apiURL, apiKeyOrBasicAuth string, client *http.Client) (*Client,
 		parts := strings.Split(apiKeyOrBasicAuth, ":")
 		baseURL.User = url.UserPassword(parts[0], parts[1])
 	}
-	return &Client{baseURL: baseURL.String(), basicAuth: basicAuth, key: key, client: client}, nil
+	c := &Client{baseURL: baseURL.String(), basicAuth: basicAuth, key: key, client: client}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// NewClientWithTokenSource initializes a client that authenticates every
+// request with a token obtained from source instead of a static API key,
+// re-fetching it on each call so rotated or short-lived tokens stay valid
+// without the caller having to recreate the Client.
+func NewClientWithTokenSource(apiURL string, source TokenSource, client *http.Client, opts ...ClientOption) (*Client, error) {
+	baseURL, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{baseURL: baseURL.String(), client: client, tokenSource: source}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 func (r *Client) get(ctx context.Context, query string, params url.Values) ([]byte, int, error) {
@@ -114,22 +182,57 @@ func (r *Client) getWithRawPath(ctx context.Context, query, rawPath string, para
 }
 
 func (r *Client) patch(ctx context.Context, query string, params url.Values, body []byte) ([]byte, int, error) {
-	return r.doRequest(ctx, "PATCH", query, "", params, bytes.NewBuffer(body))
+	return r.doRequest(ctx, "PATCH", query, "", params, body)
 }
 
 func (r *Client) put(ctx context.Context, query string, params url.Values, body []byte) ([]byte, int, error) {
-	return r.doRequest(ctx, "PUT", query, "", params, bytes.NewBuffer(body))
+	return r.doRequest(ctx, "PUT", query, "", params, body)
 }
 
 func (r *Client) post(ctx context.Context, query string, params url.Values, body []byte) ([]byte, int, error) {
-	return r.doRequest(ctx, "POST", query, "", params, bytes.NewBuffer(body))
+	return r.doRequest(ctx, "POST", query, "", params, body)
 }
 
 func (r *Client) delete(ctx context.Context, query string) ([]byte, int, error) {
 	return r.doRequest(ctx, "DELETE", query, "", nil, nil)
 }
 
-func (r *Client) doRequest(ctx context.Context, method, query, rawPath string, params url.Values, buf io.Reader) ([]byte, int, error) {
+// doRequest performs a single logical HTTP call against the Grafana API,
+// transparently retrying it when the client was configured with WithRetry
+// and the failure looks transient (network error, 429, 502, 503 or 504).
+// body is buffered up front (rather than accepted as an io.Reader) so that
+// it can be replayed on every retry attempt.
+func (r *Client) doRequest(ctx context.Context, method, query, rawPath string, params url.Values, body []byte) ([]byte, int, error) {
+	var (
+		data       []byte
+		statusCode int
+		err        error
+	)
+	transport := r.transport()
+	var retryAfter time.Duration
+	for attempt := 0; ; attempt++ {
+		data, statusCode, retryAfter, err = transport.RoundTrip(ctx, method, query, rawPath, params, body)
+		if attempt >= r.retryMaxAttempts || !isRetryable(err) {
+			return data, statusCode, err
+		}
+
+		delay := retryAfter
+		if delay <= 0 {
+			delay = backoffDelay(r.retryBaseDelay, attempt)
+		}
+		select {
+		case <-ctx.Done():
+			return data, statusCode, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// doRequestOnce performs a single attempt of the request, without any
+// retry logic. When Grafana responds with a `Retry-After` header, its
+// parsed value is returned alongside the usual result so the caller can
+// honor it before the next attempt.
+func (r *Client) doRequestOnce(ctx context.Context, method, query, rawPath string, params url.Values, body []byte) ([]byte, int, time.Duration, error) {
 	u, _ := url.Parse(r.baseURL)
 	u.Path = path.Join(u.Path, query)
 	if rawPath != "" {
@@ -138,12 +241,22 @@ func (r *Client) doRequest(ctx context.Context, method, query, rawPath string, p
 	if params != nil {
 		u.RawQuery = params.Encode()
 	}
+	var buf io.Reader
+	if body != nil {
+		buf = bytes.NewBuffer(body)
+	}
 	req, err := http.NewRequest(method, u.String(), buf)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, 0, &permanentError{err}
 	}
 	req = req.WithContext(ctx)
-	if !r.basicAuth {
+	if r.tokenSource != nil {
+		token, err := r.tokenSource.Token()
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("failed to obtain token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if !r.basicAuth {
 		req.Header.Set("Authorization", r.key)
 	}
 	if r.customHeaders != nil {
@@ -156,9 +269,66 @@ func (r *Client) doRequest(ctx context.Context, method, query, rawPath string, p
 	req.Header.Set("User-Agent", "autograf")
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, 0, err
 	}
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
 	data, err := ioutil.ReadAll(resp.Body)
 	resp.Body.Close()
-	return data, resp.StatusCode, err
+	if err != nil {
+		return data, resp.StatusCode, retryAfter, err
+	}
+	if resp.StatusCode >= 400 {
+		return data, resp.StatusCode, retryAfter, newAPIError(resp.StatusCode, resp.Status, data)
+	}
+	return data, resp.StatusCode, retryAfter, nil
+}
+
+// permanentError marks an error that retrying will not fix, such as a
+// malformed request that would fail identically on every attempt.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// isRetryable reports whether a request that failed with err (which may be
+// a plain network error, a *permanentError, an *APIError, or nil) should be
+// retried.
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var permErr *permanentError
+	if errors.As(err, &permErr) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatusCodes[apiErr.StatusCode]
+	}
+	return true
+}
+
+// backoffDelay returns the exponential backoff wait before the given
+// (0-indexed) retry attempt, given the configured base delay.
+func backoffDelay(base time.Duration, attempt int) time.Duration {
+	return time.Duration(float64(base) * math.Pow(2, float64(attempt)))
+}
+
+// parseRetryAfter interprets Grafana's `Retry-After` header, which may be
+// either a number of seconds or an HTTP-date. An unparsable or empty value
+// yields a zero duration, telling the caller to fall back to its own
+// backoff schedule.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(when); delay > 0 {
+			return delay
+		}
+	}
+	return 0
 }
@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	past := time.Now().Add(-10 * time.Second).UTC().Format(http.TimeFormat)
+
+	cases := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"empty", "", 0},
+		{"seconds", "5", 5 * time.Second},
+		{"http-date-in-past", past, 0},
+		{"garbage", "not-a-duration", 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parseRetryAfter(tc.header); got != tc.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tc.header, got, tc.want)
+			}
+		})
+	}
+
+	// The HTTP-date case is checked with a tolerance instead of an exact
+	// value, since the header only has one-second resolution.
+	t.Run("http-date-in-future", func(t *testing.T) {
+		got := parseRetryAfter(future)
+		if got <= 0 || got > 11*time.Second {
+			t.Errorf("parseRetryAfter(%q) = %v, want roughly 10s", future, got)
+		}
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"network error", errors.New("connection reset"), true},
+		{"permanent error", &permanentError{errors.New("bad url")}, false},
+		{"rate limited", &APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{"bad gateway", &APIError{StatusCode: http.StatusBadGateway}, true},
+		{"not found", &APIError{StatusCode: http.StatusNotFound}, false},
+		{"unauthorized", &APIError{StatusCode: http.StatusUnauthorized}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryable(tc.err); got != tc.want {
+				t.Errorf("isRetryable(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	base := 100 * time.Millisecond
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{2, 400 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+	}
+	for _, tc := range cases {
+		if got := backoffDelay(base, tc.attempt); got != tc.want {
+			t.Errorf("backoffDelay(%v, %d) = %v, want %v", base, tc.attempt, got, tc.want)
+		}
+	}
+}